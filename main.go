@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,6 +21,10 @@ import (
 	"unicode"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/MideGiwa/string-analyzer-api/middleware"
+	"github.com/MideGiwa/string-analyzer-api/query"
 )
 
 // StringProperties holds the computed properties of a string....
@@ -36,12 +45,6 @@ type AnalyzedString struct {
 	CreatedAt  time.Time        `json:"created_at"`
 }
 
-// Store for analyzed strings (in-memory)....
-var (
-	stringStore = make(map[string]AnalyzedString)
-	mu          sync.RWMutex // Mutex to protect stringStore...
-)
-
 // Request body for POST /strings....
 type CreateStringRequest struct {
 	Value string `json:"value" binding:"required"`
@@ -51,6 +54,9 @@ type CreateStringRequest struct {
 type FilteredStringsResponse struct {
 	Data           []AnalyzedString       `json:"data"`
 	Count          int                    `json:"count"`
+	Total          int                    `json:"total"`
+	Offset         int                    `json:"offset"`
+	Limit          int                    `json:"limit"`
 	FiltersApplied map[string]interface{} `json:"filters_applied"`
 }
 
@@ -58,12 +64,67 @@ type FilteredStringsResponse struct {
 type NaturalLanguageFilterResponse struct {
 	Data             []AnalyzedString     `json:"data"`
 	Count            int                  `json:"count"`
+	Total            int                  `json:"total"`
+	Offset           int                  `json:"offset"`
+	Limit            int                  `json:"limit"`
 	InterpretedQuery NaturalLanguageQuery `json:"interpreted_query"`
 }
 
+// NaturalLanguageQuery echoes the request and the canonical rewrite of
+// the predicate the query engine compiled it into....
 type NaturalLanguageQuery struct {
-	Original      string                 `json:"original"`
-	ParsedFilters map[string]interface{} `json:"parsed_filters"`
+	Original  string `json:"original"`
+	Rewritten string `json:"rewritten"`
+}
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// parsePaginationParams reads the `offset` and `limit` query parameters,
+// applying defaults and bounds, and passes the `sort` parameter through
+// unvalidated (Store.List rejects an unknown sort). It returns an error
+// describing the first invalid value encountered....
+func parsePaginationParams(c *gin.Context) (offset, limit int, sortParam string, err error) {
+	offset = 0
+	if param := c.Query("offset"); param != "" {
+		offset, err = strconv.Atoi(param)
+		if err != nil || offset < 0 {
+			return 0, 0, "", fmt.Errorf("invalid value for 'offset', must be a non-negative integer")
+		}
+	}
+
+	limit = defaultLimit
+	if param := c.Query("limit"); param != "" {
+		limit, err = strconv.Atoi(param)
+		if err != nil || limit < 0 {
+			return 0, 0, "", fmt.Errorf("invalid value for 'limit', must be a non-negative integer")
+		}
+		if limit > maxLimit {
+			return 0, 0, "", fmt.Errorf("invalid value for 'limit', must not exceed %d", maxLimit)
+		}
+	}
+
+	return offset, limit, c.Query("sort"), nil
+}
+
+// respondListError translates a Store.List error into an HTTP response: a
+// context deadline or client disconnect becomes 504 Gateway Timeout, an
+// invalid sort (the one client-caused List error) becomes 400 with the
+// offending detail, and anything else is a backend failure and becomes
+// 500 with a generic message so store internals (e.g. a Redis error)
+// never reach the client....
+func respondListError(c *gin.Context, err error) {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Request timed out while scanning strings"})
+		return
+	}
+	if errors.Is(err, ErrInvalidSort) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list strings"})
 }
 
 // calculateLength returns the number of characters in a string....
@@ -142,8 +203,18 @@ func analyzeString(value string) StringProperties {
 	}
 }
 
+// API holds the dependencies shared by the string-analyzer handlers....
+type API struct {
+	store Store
+}
+
+// NewAPI returns an API backed by store....
+func NewAPI(store Store) *API {
+	return &API{store: store}
+}
+
 // CreateStringHandler handles POST /strings....
-func CreateStringHandler(c *gin.Context) {
+func (a *API) CreateStringHandler(c *gin.Context) {
 	var req CreateStringRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		// Check for missing "value" field....
@@ -160,15 +231,6 @@ func CreateStringHandler(c *gin.Context) {
 		return
 	}
 
-	mu.RLock()
-	existingHash := generateSHA256Hash(req.Value)
-	if _, exists := stringStore[existingHash]; exists {
-		mu.RUnlock()
-		c.JSON(http.StatusConflict, gin.H{"error": "String already exists in the system"})
-		return
-	}
-	mu.RUnlock()
-
 	properties := analyzeString(req.Value)
 
 	newAnalyzedString := AnalyzedString{
@@ -178,342 +240,418 @@ func CreateStringHandler(c *gin.Context) {
 		CreatedAt:  time.Now().UTC(),
 	}
 
-	mu.Lock()
-	stringStore[newAnalyzedString.ID] = newAnalyzedString
-	mu.Unlock()
+	if err := a.store.Create(newAnalyzedString); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "String already exists in the system"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store string"})
+		return
+	}
 
 	c.JSON(http.StatusCreated, newAnalyzedString)
 }
 
-// GetSpecificStringHandler handles GET /strings/{string_value}....
-func GetSpecificStringHandler(c *gin.Context) {
-	stringValue := c.Param("string_value")
+// BatchCreateRequest is the shape of a single element of a POST
+// /strings/batch payload, whether the body is a JSON array of these or an
+// application/x-ndjson stream of one per line....
+type BatchCreateRequest struct {
+	Value string `json:"value"`
+}
 
-	mu.RLock()
-	defer mu.RUnlock()
+// BatchResult is one line of the streamed NDJSON response from
+// BatchCreateHandler, reporting the outcome of a single element by its
+// position in the request....
+type BatchResult struct {
+	Index  int    `json:"index"`
+	Status string `json:"status"` // "created", "conflict", "error", "timeout", or "cancelled"
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
 
-	// Try to find by direct hash....
-	if analyzedStr, exists := stringStore[stringValue]; exists {
-		c.JSON(http.StatusOK, analyzedStr)
+const (
+	batchStatusCreated   = "created"
+	batchStatusConflict  = "conflict"
+	batchStatusError     = "error"
+	batchStatusTimeout   = "timeout"
+	batchStatusCancelled = "cancelled"
+)
+
+// batchJob is a single string pulled off the request body, tagged with
+// its position so BatchResult.Index survives out-of-order completion by
+// the worker pool....
+type batchJob struct {
+	index int
+	value string
+}
+
+// BatchCreateHandler handles POST /strings/batch. It accepts either a
+// JSON array of BatchCreateRequest objects or an application/x-ndjson
+// body of one per line, analyzes them concurrently across a bounded pool
+// of runtime.NumCPU() workers, and streams back an NDJSON response of
+// BatchResult lines as each completes. The request body is read
+// incrementally via json.Decoder, so neither the request nor the
+// response is ever buffered in full....
+func (a *API) BatchCreateHandler(c *gin.Context) {
+	contentType := c.ContentType()
+	if contentType != "" && contentType != "application/json" && contentType != "application/x-ndjson" {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be application/json or application/x-ndjson"})
 		return
 	}
 
-	// If not found by direct hash, try to hash the param and find....
-	hashedValue := generateSHA256Hash(stringValue)
-	if analyzedStr, exists := stringStore[hashedValue]; exists {
-		c.JSON(http.StatusOK, analyzedStr)
+	jobs := make(chan batchJob)
+	results := make(chan BatchResult)
+
+	workers := runtime.NumCPU()
+	if workers < 1 {
+		workers = 1
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results <- a.processBatchJob(job)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	decodeErrCh := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		decodeErrCh <- decodeBatchRequests(c.Request.Context(), c.Request, contentType == "application/x-ndjson", jobs)
+	}()
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+	encoder := json.NewEncoder(c.Writer)
+	flusher, canFlush := c.Writer.(http.Flusher)
+
+	// Keep draining results until the channel closes even once writing
+	// fails (e.g. the client disconnected), so the workers blocked on
+	// results<- above are never left stranded....
+	var writeErr error
+	for result := range results {
+		if writeErr != nil {
+			continue
+		}
+		if err := encoder.Encode(result); err != nil {
+			writeErr = err
+			continue
+		}
+		if canFlush {
+			flusher.Flush()
+		}
+	}
+	if writeErr != nil {
 		return
 	}
 
-	c.JSON(http.StatusNotFound, gin.H{"error": "String not found in the system"})
+	if err := <-decodeErrCh; err != nil {
+		result := BatchResult{Index: -1, Status: batchStatusError, Error: fmt.Sprintf("malformed request body: %v", err)}
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			result = BatchResult{Index: -1, Status: batchStatusTimeout, Error: "request timed out before the body was fully ingested"}
+		case errors.Is(err, context.Canceled):
+			result = BatchResult{Index: -1, Status: batchStatusCancelled, Error: "request cancelled before the body was fully ingested"}
+		}
+		encoder.Encode(result)
+		if canFlush {
+			flusher.Flush()
+		}
+	}
 }
 
-// GetFilteredStringsHandler handles GET /strings with filtering....
-func GetFilteredStringsHandler(c *gin.Context) {
-	filters := make(map[string]interface{})
-	filteredStrings := make([]AnalyzedString, 0) // Initialize as empty slice, not nil
+// processBatchJob analyzes and stores a single batch element, translating
+// the outcome into a BatchResult....
+func (a *API) processBatchJob(job batchJob) BatchResult {
+	properties := analyzeString(job.value)
+	newAnalyzedString := AnalyzedString{
+		ID:         properties.SHA256Hash,
+		Value:      job.value,
+		Properties: properties,
+		CreatedAt:  time.Now().UTC(),
+	}
 
-	mu.RLock()
-	defer mu.RUnlock()
+	if err := a.store.Create(newAnalyzedString); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return BatchResult{Index: job.index, Status: batchStatusConflict, ID: newAnalyzedString.ID}
+		}
+		return BatchResult{Index: job.index, Status: batchStatusError, Error: err.Error()}
+	}
 
-	for _, str := range stringStore {
-		match := true
+	return BatchResult{Index: job.index, Status: batchStatusCreated, ID: newAnalyzedString.ID}
+}
 
-		// is_palindrome filter....
-		if param := c.Query("is_palindrome"); param != "" {
-			val, err := strconv.ParseBool(param)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'is_palindrome', must be boolean (true/false)"})
-				return
-			}
-			filters["is_palindrome"] = val
-			if str.Properties.IsPalindrome != val {
-				match = false
-			}
-		}
+// decodeBatchRequests reads r.Body incrementally via json.Decoder,
+// pushing one batchJob per element onto jobs, and returns once the body
+// is exhausted, a decode error occurs, or ctx is cancelled....
+func decodeBatchRequests(ctx context.Context, r *http.Request, ndjson bool, jobs chan<- batchJob) error {
+	dec := json.NewDecoder(r.Body)
+	if ndjson {
+		return decodeNDJSONBatch(ctx, dec, jobs)
+	}
+	return decodeJSONArrayBatch(ctx, dec, jobs)
+}
 
-		// min_length filter....
-		if param := c.Query("min_length"); param != "" {
-			val, err := strconv.Atoi(param)
-			if err != nil || val < 0 {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'min_length', must be a non-negative integer"})
-				return
-			}
-			filters["min_length"] = val
-			if str.Properties.Length < val {
-				match = false
-			}
+// decodeNDJSONBatch decodes one BatchCreateRequest per line (per JSON
+// value, strictly speaking) until EOF....
+func decodeNDJSONBatch(ctx context.Context, dec *json.Decoder, jobs chan<- batchJob) error {
+	for index := 0; ; index++ {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-
-		// max_length filter....
-		if param := c.Query("max_length"); param != "" {
-			val, err := strconv.Atoi(param)
-			if err != nil || val < 0 {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'max_length', must be a non-negative integer"})
-				return
-			}
-			filters["max_length"] = val
-			if str.Properties.Length > val {
-				match = false
+		var req BatchCreateRequest
+		if err := dec.Decode(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
 			}
+			return fmt.Errorf("line %d: %w", index+1, err)
 		}
+		jobs <- batchJob{index: index, value: req.Value}
+	}
+}
 
-		// word_count filter....
-		if param := c.Query("word_count"); param != "" {
-			val, err := strconv.Atoi(param)
-			if err != nil || val < 0 {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'word_count', must be a non-negative integer"})
-				return
-			}
-			filters["word_count"] = val
-			if str.Properties.WordCount != val {
-				match = false
-			}
-		}
+// decodeJSONArrayBatch decodes a top-level JSON array one element at a
+// time via dec.Token/dec.More, so the array is never materialized in
+// full....
+func decodeJSONArrayBatch(ctx context.Context, dec *json.Decoder, jobs chan<- batchJob) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON array: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected a JSON array")
+	}
 
-		// contains_character filter....
-		if param := c.Query("contains_character"); param != "" {
-			if len([]rune(param)) != 1 {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'contains_character', must be a single character"})
-				return
-			}
-			charToFind := []rune(param)[0]
-			filters["contains_character"] = param
-			found := false
-			for c := range str.Properties.CharacterFrequencyMap {
-				if c == charToFind {
-					found = true
-					break
-				}
-			}
-			if !found {
-				match = false
-			}
+	for index := 0; dec.More(); index++ {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-
-		if match {
-			filteredStrings = append(filteredStrings, str)
+		var req BatchCreateRequest
+		if err := dec.Decode(&req); err != nil {
+			return fmt.Errorf("element %d: %w", index, err)
 		}
+		jobs <- batchJob{index: index, value: req.Value}
 	}
 
-	c.JSON(http.StatusOK, FilteredStringsResponse{
-		Data:           filteredStrings,
-		Count:          len(filteredStrings),
-		FiltersApplied: filters,
-	})
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("invalid JSON array: %w", err)
+	}
+	return nil
 }
 
-// ParseNaturalLanguageQuery attempts to parse a natural language query into structured filters....
-func ParseNaturalLanguageQuery(query string) (map[string]interface{}, error) {
-	parsedFilters := make(map[string]interface{})
-	lowerQuery := strings.ToLower(query)
-
-	// Palindrome....
-	if strings.Contains(lowerQuery, "palindrome") || strings.Contains(lowerQuery, "palindromic") {
-		parsedFilters["is_palindrome"] = true
-	}
-
-	// Word Count....
-	reWordCount := regexp.MustCompile(`(single|one|two|three|four|five|six|seven|eight|nine|ten) word`)
-	if matches := reWordCount.FindStringSubmatch(lowerQuery); len(matches) > 1 {
-		switch matches[1] {
-		case "single", "one":
-			parsedFilters["word_count"] = 1
-		case "two":
-			parsedFilters["word_count"] = 2
-		case "three":
-			parsedFilters["word_count"] = 3
-		case "four":
-			parsedFilters["word_count"] = 4
-		case "five":
-			parsedFilters["word_count"] = 5
-		// Add more cases as needed....
-		default:
-			return nil, fmt.Errorf("unsupported word count '%s'", matches[1])
-		}
-	} else {
-		reWordCountNum := regexp.MustCompile(`(\d+) words?`) // Corrected: single backslash for \d
-		if matches := reWordCountNum.FindStringSubmatch(lowerQuery); len(matches) > 1 {
-			num, err := strconv.Atoi(matches[1])
-			if err == nil {
-				parsedFilters["word_count"] = num
-			}
-		}
+// GetSpecificStringHandler handles GET /strings/{string_value}....
+func (a *API) GetSpecificStringHandler(c *gin.Context) {
+	stringValue := c.Param("string_value")
+
+	// Try to find by direct hash....
+	analyzedStr, err := a.store.Get(stringValue)
+	if err == nil {
+		c.JSON(http.StatusOK, analyzedStr)
+		return
+	}
+	if !errors.Is(err, ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve string"})
+		return
 	}
 
-	// Length filters....
-	reLongerThan := regexp.MustCompile(`longer than (\d+)`)
-	if matches := reLongerThan.FindStringSubmatch(lowerQuery); len(matches) > 1 {
-		num, err := strconv.Atoi(matches[1])
-		if err == nil {
-			if existingMin, ok := parsedFilters["min_length"].(int); ok && existingMin > num+1 {
-				// Conflict: already has a higher min_length....
-				return nil, fmt.Errorf("conflicting length filters detected")
-			}
-			parsedFilters["min_length"] = num + 1 // "longer than X" means min_length = X + 1....
+	// If not found by direct hash, try to hash the param and find....
+	hashedValue := generateSHA256Hash(stringValue)
+	analyzedStr, err = a.store.Get(hashedValue)
+	if err == nil {
+		c.JSON(http.StatusOK, analyzedStr)
+		return
+	}
+	if !errors.Is(err, ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve string"})
+		return
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "String not found in the system"})
+}
+
+// GetFilteredStringsHandler handles GET /strings with filtering....
+func (a *API) GetFilteredStringsHandler(c *gin.Context) {
+	offset, limit, sortParam, err := parsePaginationParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var filters Filters
+	filtersApplied := make(map[string]interface{})
+
+	// is_palindrome filter....
+	if param := c.Query("is_palindrome"); param != "" {
+		val, err := strconv.ParseBool(param)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'is_palindrome', must be boolean (true/false)"})
+			return
 		}
+		filters.IsPalindrome = &val
+		filtersApplied["is_palindrome"] = val
 	}
 
-	reShorterThan := regexp.MustCompile(`shorter than (\d+)`)
-	if matches := reShorterThan.FindStringSubmatch(lowerQuery); len(matches) > 1 {
-		num, err := strconv.Atoi(matches[1])
-		if err == nil {
-			if existingMax, ok := parsedFilters["max_length"].(int); ok && existingMax < num-1 {
-				// Conflict: already has a lower max_length....
-				return nil, fmt.Errorf("conflicting length filters detected")
-			}
-			parsedFilters["max_length"] = num - 1 // "shorter than X" means max_length = X - 1....
+	// min_length filter....
+	if param := c.Query("min_length"); param != "" {
+		val, err := strconv.Atoi(param)
+		if err != nil || val < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'min_length', must be a non-negative integer"})
+			return
 		}
+		filters.MinLength = &val
+		filtersApplied["min_length"] = val
 	}
 
-	reExactlyLength := regexp.MustCompile(`exactly (\d+)`)
-	if matches := reExactlyLength.FindStringSubmatch(lowerQuery); len(matches) > 1 {
-		num, err := strconv.Atoi(matches[1])
-		if err == nil {
-			// Check for conflicts with min/max length....
-			if existingMin, ok := parsedFilters["min_length"].(int); ok && existingMin > num {
-				return nil, fmt.Errorf("conflicting length filters detected")
-			}
-			if existingMax, ok := parsedFilters["max_length"].(int); ok && existingMax < num {
-				return nil, fmt.Errorf("conflicting length filters detected")
-			}
-			parsedFilters["min_length"] = num
-			parsedFilters["max_length"] = num
+	// max_length filter....
+	if param := c.Query("max_length"); param != "" {
+		val, err := strconv.Atoi(param)
+		if err != nil || val < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'max_length', must be a non-negative integer"})
+			return
 		}
+		filters.MaxLength = &val
+		filtersApplied["max_length"] = val
 	}
 
-	// Contains character....
-	reContainsChar := regexp.MustCompile(`contains the letter ([a-z])`)
-	if matches := reContainsChar.FindStringSubmatch(lowerQuery); len(matches) > 1 {
-		parsedFilters["contains_character"] = matches[1]
-	} else {
-		if strings.Contains(lowerQuery, "contains the first vowel") {
-			parsedFilters["contains_character"] = "a"
+	// word_count filter....
+	if param := c.Query("word_count"); param != "" {
+		val, err := strconv.Atoi(param)
+		if err != nil || val < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'word_count', must be a non-negative integer"})
+			return
 		}
+		filters.WordCount = &val
+		filtersApplied["word_count"] = val
 	}
 
-	// Check for overall min_length > max_length conflict....
-	if minLen, okMin := parsedFilters["min_length"].(int); okMin {
-		if maxLen, okMax := parsedFilters["max_length"].(int); okMax {
-			if minLen > maxLen {
-				return nil, fmt.Errorf("query resulted in conflicting length filters (min_length > max_length)")
-			}
+	// contains_character filter....
+	if param := c.Query("contains_character"); param != "" {
+		runes := []rune(param)
+		if len(runes) != 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid value for 'contains_character', must be a single character"})
+			return
 		}
+		filters.ContainsCharacter = &runes[0]
+		filtersApplied["contains_character"] = param
 	}
 
-	if len(parsedFilters) == 0 {
-		return nil, fmt.Errorf("unable to parse natural language query into filters")
+	page, total, err := a.store.List(c.Request.Context(), filters, Pagination{Offset: offset, Limit: limit, Sort: sortParam})
+	if err != nil {
+		respondListError(c, err)
+		return
 	}
 
-	return parsedFilters, nil
+	c.JSON(http.StatusOK, FilteredStringsResponse{
+		Data:           page,
+		Count:          len(page),
+		Total:          total,
+		Offset:         offset,
+		Limit:          limit,
+		FiltersApplied: filtersApplied,
+	})
+}
+
+// candidateFromAnalyzedString converts an AnalyzedString into the
+// query.Candidate an AST predicate is evaluated against....
+func candidateFromAnalyzedString(s AnalyzedString) query.Candidate {
+	return query.Candidate{
+		Value:                 s.Value,
+		Length:                s.Properties.Length,
+		IsPalindrome:          s.Properties.IsPalindrome,
+		UniqueCharacters:      s.Properties.UniqueCharacters,
+		WordCount:             s.Properties.WordCount,
+		SHA256Hash:            s.Properties.SHA256Hash,
+		CharacterFrequencyMap: s.Properties.CharacterFrequencyMap,
+	}
 }
 
 // NaturalLanguageFilterHandler handles GET /strings/filter-by-natural-language....
-func NaturalLanguageFilterHandler(c *gin.Context) {
-	query := c.Query("query")
-	if query == "" {
+func (a *API) NaturalLanguageFilterHandler(c *gin.Context) {
+	rawQuery := c.Query("query")
+	if rawQuery == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing 'query' parameter"})
 		return
 	}
 
-	parsedFilters, err := ParseNaturalLanguageQuery(query)
+	predicate, err := query.Parse(rawQuery)
 	if err != nil {
-		if strings.Contains(err.Error(), "conflicting") {
-			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Conflicting filters detected in query"})
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		var parseErr *query.ParseError
+		if errors.As(err, &parseErr) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":    parseErr.Message,
+				"span":     parseErr.Span,
+				"position": parseErr.Pos,
+			})
+			return
 		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	filteredStrings := make([]AnalyzedString, 0) // Initialize as empty slice, not nil
-	mu.RLock()
-	defer mu.RUnlock()
-
-	for _, str := range stringStore {
-		match := true
-
-		// Apply parsed filters....
-		for filterKey, filterValue := range parsedFilters {
-			switch filterKey {
-			case "is_palindrome":
-				if val, ok := filterValue.(bool); ok && str.Properties.IsPalindrome != val {
-					match = false
-				}
-			case "min_length":
-				if val, ok := filterValue.(int); ok && str.Properties.Length < val {
-					match = false
-				}
-			case "max_length":
-				if val, ok := filterValue.(int); ok && str.Properties.Length > val {
-					match = false
-				}
-			case "word_count":
-				if val, ok := filterValue.(int); ok && str.Properties.WordCount != val {
-					match = false
-				}
-			case "contains_character":
-				if val, ok := filterValue.(string); ok {
-					charToFind := []rune(val)[0]
-					found := false
-					for c := range str.Properties.CharacterFrequencyMap {
-						if c == charToFind {
-							found = true
-							break
-						}
-					}
-					if !found {
-						match = false
-					}
-				}
-			}
-			if !match {
-				break
-			}
-		}
+	offset, limit, sortParam, err := parsePaginationParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-		if match {
-			filteredStrings = append(filteredStrings, str)
-		}
+	filters := Filters{
+		Predicate: func(s AnalyzedString) bool { return predicate.Eval(candidateFromAnalyzedString(s)) },
+	}
+
+	page, total, err := a.store.List(c.Request.Context(), filters, Pagination{Offset: offset, Limit: limit, Sort: sortParam})
+	if err != nil {
+		respondListError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, NaturalLanguageFilterResponse{
-		Data:  filteredStrings,
-		Count: len(filteredStrings),
+		Data:   page,
+		Count:  len(page),
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
 		InterpretedQuery: NaturalLanguageQuery{
-			Original:      query,
-			ParsedFilters: parsedFilters,
+			Original:  rawQuery,
+			Rewritten: predicate.Explain(),
 		},
 	})
 }
 
 // DeleteStringHandler handles DELETE /strings/{string_value}....
-func DeleteStringHandler(c *gin.Context) {
+func (a *API) DeleteStringHandler(c *gin.Context) {
 	stringValue := c.Param("string_value")
 
-	mu.Lock()
-	defer mu.Unlock()
-
-	deleted := false
 	// Try to delete by direct hash....
-	if _, exists := stringStore[stringValue]; exists {
-		delete(stringStore, stringValue)
-		deleted = true
-	} else {
-		// If not found by direct hash, try to hash the param and delete....
-		hashedValue := generateSHA256Hash(stringValue)
-		if _, exists := stringStore[hashedValue]; exists {
-			delete(stringStore, hashedValue)
-			deleted = true
-		}
+	err := a.store.Delete(stringValue)
+	if err == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	if !errors.Is(err, ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete string"})
+		return
 	}
 
-	if !deleted {
-		c.JSON(http.StatusNotFound, gin.H{"error": "String not found in the system"})
+	// If not found by direct hash, try to hash the param and delete....
+	hashedValue := generateSHA256Hash(stringValue)
+	err = a.store.Delete(hashedValue)
+	if err == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	if !errors.Is(err, ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete string"})
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusNotFound, gin.H{"error": "String not found in the system"})
 }
 
 // HealthCheckHandler handles GET /health....
@@ -534,6 +672,7 @@ func RootHandler(c *gin.Context) {
 		"version":     "1.0.0",
 		"endpoints": gin.H{
 			"POST /strings":                           "Create and analyze a new string",
+			"POST /strings/batch":                     "Bulk create strings from a JSON array or NDJSON stream",
 			"GET /strings":                            "Get all strings with optional filtering",
 			"GET /strings/:string_value":              "Get a specific string by value or hash",
 			"GET /strings/filter-by-natural-language": "Filter strings using natural language queries",
@@ -544,26 +683,108 @@ func RootHandler(c *gin.Context) {
 	})
 }
 
+// newStoreFromEnv builds the Store backend selected by the STORE env var
+// (`memory`, the default, or `redis`, configured via REDIS_ADDR and
+// REDIS_DB)....
+func newStoreFromEnv() (Store, error) {
+	switch backend := os.Getenv("STORE"); backend {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "redis":
+		addr := os.Getenv("REDIS_ADDR")
+		if addr == "" {
+			addr = "localhost:6379"
+		}
+
+		db := 0
+		if raw := os.Getenv("REDIS_DB"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid REDIS_DB %q: %w", raw, err)
+			}
+			db = parsed
+		}
+
+		client := redis.NewClient(&redis.Options{Addr: addr, DB: db})
+		return NewRedisStore(client), nil
+	default:
+		return nil, fmt.Errorf("unsupported STORE backend %q, must be 'memory' or 'redis'", backend)
+	}
+}
+
+// gzipMinSizeFromEnv reads GZIP_MIN_SIZE, falling back to the middleware's
+// own default when unset or invalid....
+func gzipMinSizeFromEnv() int {
+	raw := os.Getenv("GZIP_MIN_SIZE")
+	if raw == "" {
+		return 0
+	}
+	size, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// requestTimeoutFromEnv reads REQUEST_TIMEOUT (a time.ParseDuration string,
+// e.g. "5s"), falling back to middleware.DefaultRequestTimeout when unset
+// or invalid....
+func requestTimeoutFromEnv() time.Duration {
+	raw := os.Getenv("REQUEST_TIMEOUT")
+	if raw == "" {
+		return middleware.DefaultRequestTimeout
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return middleware.DefaultRequestTimeout
+	}
+	return d
+}
+
 func main() {
-	router := gin.Default()
-
-	// Use logging middleware....
-	router.Use(func(c *gin.Context) {
-		start := time.Now()
-		c.Next() // Process the request....
-		duration := time.Since(start)
-		log.Printf("Request - Method: %s, Path: %s, Status: %d, Duration: %s",
-			c.Request.Method, c.Request.URL.Path, c.Writer.Status(), duration)
-	})
+	store, err := newStoreFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize store: %v", err)
+	}
+	api := NewAPI(store)
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	router := gin.New()
+
+	// Middleware suite: request ID propagation, a per-request deadline,
+	// structured logging, panic recovery, CORS, and gzip compression, in
+	// that order so every later middleware (and every handler) can rely on
+	// the request ID and the deadline, and so the logger, which wraps
+	// recovery, still logs a panicked request with the 500 that recovery
+	// wrote. /strings/batch is exempt from the
+	// deadline: its duration is bounded by payload size, not backend
+	// latency, so a fixed scan-oriented timeout would cut off a large
+	// ingest mid-stream....
+	router.Use(
+		middleware.RequestID(),
+		middleware.Timeout(requestTimeoutFromEnv(), "/strings/batch"),
+		middleware.StructuredLogger(logger),
+		middleware.Recovery(func(c *gin.Context, recovered interface{}) {
+			logger.Error("panic recovered",
+				"error", recovered,
+				"path", c.Request.URL.Path,
+				"request_id", middleware.FromContext(c.Request.Context()),
+			)
+		}),
+		middleware.CORS(middleware.CORSConfigFromEnv()),
+		middleware.Gzip(gzipMinSizeFromEnv()),
+	)
 
 	// Register API endpoints....
 	router.GET("/", RootHandler)
 	router.GET("/health", HealthCheckHandler)
-	router.POST("/strings", CreateStringHandler)
-	router.GET("/strings", GetFilteredStringsHandler)
-	router.GET("/strings/:string_value", GetSpecificStringHandler)
-	router.GET("/strings/filter-by-natural-language", NaturalLanguageFilterHandler)
-	router.DELETE("/strings/:string_value", DeleteStringHandler)
+	router.POST("/strings", api.CreateStringHandler)
+	router.POST("/strings/batch", api.BatchCreateHandler)
+	router.GET("/strings", api.GetFilteredStringsHandler)
+	router.GET("/strings/:string_value", api.GetSpecificStringHandler)
+	router.GET("/strings/filter-by-natural-language", api.NaturalLanguageFilterHandler)
+	router.DELETE("/strings/:string_value", api.DeleteStringHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
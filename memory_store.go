@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore is an in-memory Store backed by a map guarded by a
+// sync.RWMutex. It is the default backend and loses all data on restart....
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data map[string]AnalyzedString
+}
+
+// NewMemoryStore returns an empty MemoryStore....
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string]AnalyzedString)}
+}
+
+func (m *MemoryStore) Create(s AnalyzedString) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.data[s.ID]; exists {
+		return ErrAlreadyExists
+	}
+	m.data[s.ID] = s
+	return nil
+}
+
+func (m *MemoryStore) Get(id string) (AnalyzedString, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, exists := m.data[id]
+	if !exists {
+		return AnalyzedString{}, ErrNotFound
+	}
+	return s, nil
+}
+
+func (m *MemoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.data[id]; !exists {
+		return ErrNotFound
+	}
+	delete(m.data, id)
+	return nil
+}
+
+func (m *MemoryStore) Exists(hash string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, exists := m.data[hash]
+	return exists, nil
+}
+
+func (m *MemoryStore) List(ctx context.Context, filters Filters, pagination Pagination) ([]AnalyzedString, int, error) {
+	sortFn, err := resolveSortFn(pagination.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matched := make([]AnalyzedString, 0)
+	i := 0
+	for _, s := range m.data {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, 0, err
+			}
+		}
+		i++
+
+		if matchesFilters(s, filters) {
+			matched = append(matched, s)
+		}
+	}
+
+	page, total := paginate(matched, pagination.Offset, pagination.Limit, sortFn)
+	return page, total, nil
+}
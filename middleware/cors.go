@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig describes the allowed origins, methods, and headers for the
+// CORS middleware....
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+}
+
+// CORSConfigFromEnv builds a CORSConfig from CORS_ALLOWED_ORIGINS,
+// CORS_ALLOWED_METHODS, and CORS_ALLOWED_HEADERS (comma-separated env
+// vars), falling back to permissive defaults....
+func CORSConfigFromEnv() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: splitEnv("CORS_ALLOWED_ORIGINS", []string{"*"}),
+		AllowedMethods: splitEnv("CORS_ALLOWED_METHODS", []string{"GET", "POST", "DELETE", "OPTIONS"}),
+		AllowedHeaders: splitEnv("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization"}),
+	}
+}
+
+func splitEnv(key string, fallback []string) []string {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+
+	parts := strings.Split(raw, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// CORS returns middleware that sets Access-Control-* headers per cfg and
+// answers preflight OPTIONS requests directly instead of passing them to
+// the route handlers....
+func CORS(cfg CORSConfig) gin.HandlerFunc {
+	allowAllOrigins := false
+	allowedOrigins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAllOrigins = true
+		}
+		allowedOrigins[origin] = true
+	}
+
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin != "" && (allowAllOrigins || allowedOrigins[origin]) {
+			if allowAllOrigins {
+				c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+				c.Writer.Header().Add("Vary", "Origin")
+			}
+			c.Writer.Header().Set("Access-Control-Allow-Methods", methods)
+			c.Writer.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultGzipMinSize is the minimum response size, in bytes, before Gzip
+// compresses the body....
+const defaultGzipMinSize = 1024
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(nil) },
+}
+
+// gzipResponseWriter sniffs up to minSize bytes of the response before
+// deciding whether to compress: small responses are passed through
+// as-is, larger ones are compressed by streaming every subsequent Write
+// straight through a gzip.Writer. This keeps normal handlers' whole-body
+// responses compressible without ever buffering a streaming handler's
+// full output the way a buffer-then-compress design would....
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	minSize  int
+	sniff    bytes.Buffer
+	decided  bool
+	compress bool
+	gz       *gzip.Writer
+}
+
+// decide commits the writer to compressing or passing through, flushing
+// whatever was sniffed so far down the chosen path....
+func (w *gzipResponseWriter) decide(compress bool) error {
+	w.decided = true
+	w.compress = compress
+
+	if compress {
+		w.gz = gzipWriterPool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+		w.ResponseWriter.Header().Set("Content-Encoding", "gzip")
+		w.ResponseWriter.Header().Del("Content-Length")
+	}
+
+	if w.sniff.Len() == 0 {
+		return nil
+	}
+	defer w.sniff.Reset()
+	if w.compress {
+		_, err := w.gz.Write(w.sniff.Bytes())
+		return err
+	}
+	_, err := w.ResponseWriter.Write(w.sniff.Bytes())
+	return err
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.compress {
+			return w.gz.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	// NDJSON responses (e.g. the batch-create endpoint) are written
+	// incrementally and flushed line by line; sniffing them for a size
+	// decision would defeat that streaming, so bypass compression for
+	// them entirely, regardless of size....
+	if strings.Contains(w.Header().Get("Content-Type"), "application/x-ndjson") {
+		if err := w.decide(false); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.sniff.Write(b)
+	if w.sniff.Len() < w.minSize {
+		return len(b), nil
+	}
+	if err := w.decide(true); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipResponseWriter) Flush() {
+	if w.compress && w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// finish completes the response: a body that never reached minSize is
+// written through exactly as sniffed, and a compressing gzip.Writer is
+// flushed and returned to the pool. It is called from a defer so it
+// still runs (with whatever was written before the panic) when a
+// downstream handler panics....
+func (w *gzipResponseWriter) finish() {
+	if !w.decided {
+		if w.sniff.Len() > 0 {
+			w.ResponseWriter.Write(w.sniff.Bytes())
+		}
+		return
+	}
+	if w.compress {
+		w.gz.Close()
+		gzipWriterPool.Put(w.gz)
+	}
+}
+
+// Gzip returns middleware that compresses responses of at least minSize
+// bytes when the client advertises gzip support via Accept-Encoding,
+// streaming the compressed bytes as they're written rather than
+// buffering the whole response, and reusing gzip.Writer instances via a
+// sync.Pool. A minSize of 0 or less uses defaultGzipMinSize. Responses
+// always carry Vary: Accept-Encoding so caches don't serve a compressed
+// body to a client that can't decode it....
+func Gzip(minSize int) gin.HandlerFunc {
+	if minSize <= 0 {
+		minSize = defaultGzipMinSize
+	}
+
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: c.Writer, minSize: minSize}
+		c.Writer = gw
+		defer func() {
+			c.Writer = gw.ResponseWriter
+			gw.finish()
+		}()
+
+		c.Next()
+	}
+}
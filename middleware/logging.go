@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StructuredLogger returns middleware that emits one structured log record
+// per request via logger, capturing method, path, status, duration, bytes
+// written, remote IP, and the request ID set by RequestID....
+func StructuredLogger(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		if raw := c.Request.URL.RawQuery; raw != "" {
+			path += "?" + raw
+		}
+
+		c.Next()
+
+		logger.Info("request",
+			"method", c.Request.Method,
+			"path", path,
+			"status", c.Writer.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"bytes", c.Writer.Size(),
+			"remote_ip", c.ClientIP(),
+			"request_id", FromContext(c.Request.Context()),
+		)
+	}
+}
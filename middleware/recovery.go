@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery returns middleware that recovers from panics in downstream
+// handlers and responds with a JSON error instead of gin's default HTML
+// stack trace. onRecover, if non-nil, is called with the recovered value
+// so the caller can log it....
+func Recovery(onRecover func(c *gin.Context, recovered interface{})) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if onRecover != nil {
+					onRecover(c, recovered)
+				}
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"error":      "internal server error",
+					"request_id": FromContext(c.Request.Context()),
+				})
+			}
+		}()
+		c.Next()
+	}
+}
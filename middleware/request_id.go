@@ -0,0 +1,54 @@
+// Package middleware provides Gin middleware for the string-analyzer API:
+// request ID propagation, CORS, gzip compression, panic recovery, and
+// structured request logging....
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+type contextKey string
+
+// requestIDKey is the context.Context key under which the request ID is
+// stored....
+const requestIDKey contextKey = "request_id"
+
+// RequestIDHeader is the response (and, if present, request) header
+// carrying the request ID....
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID assigns a unique ID to every request (reusing an inbound
+// X-Request-ID if the client already set one), exposing it via the
+// response header and via c.Request.Context()....
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = generateRequestID()
+		}
+
+		c.Writer.Header().Set(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), requestIDKey, id))
+
+		c.Next()
+	}
+}
+
+func generateRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "00000000000000000000000000000000"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// FromContext returns the request ID stored in ctx by RequestID, or ""
+// if none is set....
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
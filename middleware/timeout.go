@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRequestTimeout is used when no timeout is configured....
+const DefaultRequestTimeout = 5 * time.Second
+
+// Timeout returns middleware that bounds every request to d by wrapping
+// c.Request's context in a context.WithTimeout. It does not itself write a
+// response on expiry — handlers doing long-running work (scans, filters)
+// are expected to check ctx.Err() periodically and respond 504 Gateway
+// Timeout themselves, since only they know how to unwind safely.
+//
+// exemptPaths lists request paths left untouched by the deadline, for
+// endpoints whose duration is bounded by payload size rather than backend
+// latency (e.g. a streaming bulk ingest), where a fixed deadline would cut
+// the request off mid-stream instead of bounding genuine backend work....
+func Timeout(d time.Duration, exemptPaths ...string) gin.HandlerFunc {
+	if d <= 0 {
+		d = DefaultRequestTimeout
+	}
+
+	exempt := make(map[string]bool, len(exemptPaths))
+	for _, p := range exemptPaths {
+		exempt[p] = true
+	}
+
+	return func(c *gin.Context) {
+		if exempt[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,219 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Candidate is the read-only view of an analyzed string that a Node is
+// evaluated against. It is deliberately independent of any caller's own
+// string type so this package has no dependency on the caller's model;
+// callers convert their own representation into a Candidate....
+type Candidate struct {
+	Value                 string
+	Length                int
+	IsPalindrome          bool
+	UniqueCharacters      int
+	WordCount             int
+	SHA256Hash            string
+	CharacterFrequencyMap map[rune]int
+}
+
+// Node is a predicate in the query AST. Eval reports whether c matches
+// the predicate. Explain renders a canonical, deterministic rewrite of
+// the predicate (e.g. "(length >= 5 AND is_palindrome)") suitable for
+// display back to the caller....
+type Node interface {
+	Eval(c Candidate) bool
+	Explain() string
+}
+
+// field identifies a numeric property of a Candidate that a Comparison
+// can be evaluated against....
+type field int
+
+const (
+	fieldLength field = iota
+	fieldUniqueCharacters
+	fieldWordCount
+)
+
+func (f field) String() string {
+	switch f {
+	case fieldLength:
+		return "length"
+	case fieldUniqueCharacters:
+		return "unique_characters"
+	case fieldWordCount:
+		return "word_count"
+	default:
+		return "unknown_field"
+	}
+}
+
+func (f field) valueOf(c Candidate) int {
+	switch f {
+	case fieldLength:
+		return c.Length
+	case fieldUniqueCharacters:
+		return c.UniqueCharacters
+	case fieldWordCount:
+		return c.WordCount
+	default:
+		return 0
+	}
+}
+
+// comparator identifies how a Comparison compares a field's value against
+// Value (and, for cmpBetween, Upper)....
+type comparator int
+
+const (
+	cmpAtLeast comparator = iota
+	cmpAtMost
+	cmpMoreThan
+	cmpLessThan
+	cmpExactly
+	cmpBetween
+)
+
+// Comparison is a numeric predicate such as "length at least 5" or
+// "unique characters between 3 and 10"....
+type Comparison struct {
+	Field field
+	Cmp   comparator
+	Value int
+	Upper int // only meaningful when Cmp == cmpBetween
+}
+
+func (n Comparison) Eval(c Candidate) bool {
+	v := n.Field.valueOf(c)
+	switch n.Cmp {
+	case cmpAtLeast:
+		return v >= n.Value
+	case cmpAtMost:
+		return v <= n.Value
+	case cmpMoreThan:
+		return v > n.Value
+	case cmpLessThan:
+		return v < n.Value
+	case cmpExactly:
+		return v == n.Value
+	case cmpBetween:
+		return v >= n.Value && v <= n.Upper
+	default:
+		return false
+	}
+}
+
+func (n Comparison) Explain() string {
+	switch n.Cmp {
+	case cmpAtLeast:
+		return fmt.Sprintf("%s >= %d", n.Field, n.Value)
+	case cmpAtMost:
+		return fmt.Sprintf("%s <= %d", n.Field, n.Value)
+	case cmpMoreThan:
+		return fmt.Sprintf("%s > %d", n.Field, n.Value)
+	case cmpLessThan:
+		return fmt.Sprintf("%s < %d", n.Field, n.Value)
+	case cmpExactly:
+		return fmt.Sprintf("%s = %d", n.Field, n.Value)
+	case cmpBetween:
+		return fmt.Sprintf("%s BETWEEN %d AND %d", n.Field, n.Value, n.Upper)
+	default:
+		return "unknown_comparison"
+	}
+}
+
+// stringKind identifies the flavor of a StringPredicate....
+type stringKind int
+
+const (
+	predStartsWith stringKind = iota
+	predEndsWith
+	predContainsWord
+	predContainsCharacter
+	predHashPrefix
+)
+
+// StringPredicate is a predicate over a Candidate's text or hash, such as
+// `starts with "foo"` or `hash prefix "ab12"`....
+type StringPredicate struct {
+	Kind  stringKind
+	Value string
+}
+
+func (n StringPredicate) Eval(c Candidate) bool {
+	switch n.Kind {
+	case predStartsWith:
+		return strings.HasPrefix(c.Value, n.Value)
+	case predEndsWith:
+		return strings.HasSuffix(c.Value, n.Value)
+	case predContainsWord:
+		for _, word := range strings.Fields(c.Value) {
+			if word == n.Value {
+				return true
+			}
+		}
+		return false
+	case predContainsCharacter:
+		if len([]rune(n.Value)) != 1 {
+			return false
+		}
+		_, ok := c.CharacterFrequencyMap[[]rune(n.Value)[0]]
+		return ok
+	case predHashPrefix:
+		return strings.HasPrefix(c.SHA256Hash, n.Value)
+	default:
+		return false
+	}
+}
+
+func (n StringPredicate) Explain() string {
+	switch n.Kind {
+	case predStartsWith:
+		return fmt.Sprintf("starts_with(%q)", n.Value)
+	case predEndsWith:
+		return fmt.Sprintf("ends_with(%q)", n.Value)
+	case predContainsWord:
+		return fmt.Sprintf("contains_word(%q)", n.Value)
+	case predContainsCharacter:
+		return fmt.Sprintf("contains_character='%s'", n.Value)
+	case predHashPrefix:
+		return fmt.Sprintf("hash_prefix(%q)", n.Value)
+	default:
+		return "unknown_predicate"
+	}
+}
+
+// PalindromePredicate matches (or, with Want false, excludes) palindromes....
+type PalindromePredicate struct {
+	Want bool
+}
+
+func (n PalindromePredicate) Eval(c Candidate) bool { return c.IsPalindrome == n.Want }
+
+func (n PalindromePredicate) Explain() string {
+	if n.Want {
+		return "is_palindrome"
+	}
+	return "NOT is_palindrome"
+}
+
+// And is the conjunction of two predicates....
+type And struct{ Left, Right Node }
+
+func (n And) Eval(c Candidate) bool { return n.Left.Eval(c) && n.Right.Eval(c) }
+func (n And) Explain() string       { return fmt.Sprintf("(%s AND %s)", n.Left.Explain(), n.Right.Explain()) }
+
+// Or is the disjunction of two predicates....
+type Or struct{ Left, Right Node }
+
+func (n Or) Eval(c Candidate) bool { return n.Left.Eval(c) || n.Right.Eval(c) }
+func (n Or) Explain() string       { return fmt.Sprintf("(%s OR %s)", n.Left.Explain(), n.Right.Explain()) }
+
+// Not negates a predicate....
+type Not struct{ Node Node }
+
+func (n Not) Eval(c Candidate) bool { return !n.Node.Eval(c) }
+func (n Not) Explain() string       { return fmt.Sprintf("NOT %s", n.Node.Explain()) }
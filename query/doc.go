@@ -0,0 +1,6 @@
+// Package query implements a small grammar-driven query engine for the
+// natural-language string filter. A query is tokenized, parsed by a
+// recursive-descent parser into an AST of predicates, and the AST can
+// then be evaluated against a Candidate or rendered back into a
+// canonical, unambiguous rewrite via Explain....
+package query
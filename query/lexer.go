@@ -0,0 +1,79 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// lex tokenizes query into a slice of tokens: parenthesis, double-quoted
+// string literals, runs of digits, and runs of letters (hyphens allowed
+// mid-word, so "twenty-five" and "non-palindrome" stay single tokens).
+// Word-number composition ("one hundred") is left to the parser, which
+// sees the individual word tokens....
+func lex(query string) ([]token, error) {
+	runes := []rune(query)
+	var toks []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			toks = append(toks, token{kind: tokLParen, pos: i, raw: "("})
+			i++
+
+		case r == ')':
+			toks = append(toks, token{kind: tokRParen, pos: i, raw: ")"})
+			i++
+
+		case r == '"':
+			start := i
+			i++
+			var sb strings.Builder
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, &ParseError{Message: "unterminated string literal", Span: string(runes[start:i]), Pos: start}
+			}
+			toks = append(toks, token{kind: tokString, text: sb.String(), pos: start, raw: string(runes[start:i])})
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			raw := string(runes[start:i])
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, &ParseError{Message: "invalid number", Span: raw, Pos: start}
+			}
+			toks = append(toks, token{kind: tokNumber, num: n, pos: start, raw: raw})
+
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || runes[i] == '-') {
+				i++
+			}
+			raw := string(runes[start:i])
+			toks = append(toks, token{kind: tokWord, text: strings.ToLower(raw), pos: start, raw: raw})
+
+		default:
+			return nil, &ParseError{Message: fmt.Sprintf("unexpected character %q", r), Span: string(r), Pos: i}
+		}
+	}
+
+	return toks, nil
+}
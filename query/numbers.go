@@ -0,0 +1,41 @@
+package query
+
+import "strings"
+
+// wordNumbers maps the number words zero through twenty, and the decade
+// words twenty through ninety, to their integer value. "hundred" is
+// handled separately by wordToNumber since it composes with a preceding
+// word rather than standing for a fixed value on its own....
+var wordNumbers = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20,
+	"thirty": 30, "forty": 40, "fifty": 50, "sixty": 60, "seventy": 70,
+	"eighty": 80, "ninety": 90,
+}
+
+// wordToNumber parses a single number word, including a hyphenated decade
+// composition like "twenty-five" (20 + 5, lexed as one word by lex) and
+// the literal word "hundred" (100). It reports ok=false for anything
+// else....
+func wordToNumber(word string) (int, bool) {
+	if word == "hundred" {
+		return 100, true
+	}
+	if n, ok := wordNumbers[word]; ok {
+		return n, true
+	}
+	if tens, ones, found := strings.Cut(word, "-"); found {
+		tensVal, ok := wordNumbers[tens]
+		if !ok || tensVal < 20 || tensVal%10 != 0 {
+			return 0, false
+		}
+		onesVal, ok := wordNumbers[ones]
+		if !ok || onesVal >= 10 {
+			return 0, false
+		}
+		return tensVal + onesVal, true
+	}
+	return 0, false
+}
@@ -0,0 +1,341 @@
+package query
+
+import "fmt"
+
+// ParseError is returned by Parse when query contains a token, or lacks a
+// token, that the grammar doesn't accept at that point. Span is the
+// offending slice of the original query (or "<end of query>" when the
+// query ended too early) and Pos is its rune offset, so a caller can
+// highlight exactly where parsing failed....
+type ParseError struct {
+	Message string
+	Span    string
+	Pos     int
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %q (at position %d)", e.Message, e.Span, e.Pos)
+}
+
+// Parse tokenizes and parses query into a predicate AST.
+//
+// Grammar (informal):
+//
+//	expr       := orExpr
+//	orExpr     := andExpr ("or" andExpr)*
+//	andExpr    := notExpr ("and" notExpr)*
+//	notExpr    := "not" notExpr | primary
+//	primary    := "(" expr ")" | palindrome | stringPred | comparison
+//	comparison := field comparator quantity ("and" quantity)?   // the trailing quantity only for "between"
+//	field      := "length" | "unique characters" | "word count"
+//	comparator := "at least" | "at most" | "more than" | "less than" | "exactly" | "between"
+//	stringPred := ("starts with" | "ends with" | "contains the word" | "contains the character" | "hash prefix") STRING
+//	palindrome := ["non-"]"palindrome" | ["non-"]"palindromic"
+//
+// Any error returned is a *ParseError....
+func Parse(query string) (Node, error) {
+	toks, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: toks}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorAt(tok, "unexpected trailing input")
+	}
+	return node, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.peekAt(0) }
+
+func (p *parser) peekAt(offset int) token {
+	i := p.pos + offset
+	if i >= len(p.tokens) {
+		return token{kind: tokEOF, pos: p.endPos()}
+	}
+	return p.tokens[i]
+}
+
+func (p *parser) endPos() int {
+	if len(p.tokens) == 0 {
+		return 0
+	}
+	last := p.tokens[len(p.tokens)-1]
+	return last.pos + len([]rune(last.raw))
+}
+
+func (p *parser) next() token {
+	tok := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorAt(tok token, msg string) *ParseError {
+	span := tok.raw
+	if span == "" {
+		span = "<end of query>"
+	}
+	return &ParseError{Message: msg, Span: span, Pos: tok.pos}
+}
+
+// isWord reports whether the next token is the (already lowercased) word
+// w....
+func (p *parser) isWord(w string) bool {
+	tok := p.peek()
+	return tok.kind == tokWord && tok.text == w
+}
+
+// acceptWord consumes the next token if it is the word w....
+func (p *parser) acceptWord(w string) bool {
+	if p.isWord(w) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// acceptPhrase consumes a run of word tokens if they match words exactly
+// and in order; it consumes nothing if any word fails to match....
+func (p *parser) acceptPhrase(words ...string) bool {
+	for i, w := range words {
+		tok := p.peekAt(i)
+		if tok.kind != tokWord || tok.text != w {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+// fillerWords carry no grammatical meaning on their own, so phrases like
+// "is a palindrome" and "that is not a palindrome" both parse the same as
+// "palindrome" and "not palindrome"....
+var fillerWords = map[string]bool{"is": true, "a": true, "an": true, "the": true, "that": true}
+
+func (p *parser) skipFillers() {
+	for {
+		tok := p.peek()
+		if tok.kind != tokWord || !fillerWords[tok.text] {
+			return
+		}
+		p.next()
+	}
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Node, error) {
+	p.skipFillers()
+	if p.acceptWord("not") {
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	p.skipFillers()
+
+	if p.peek().kind == tokLParen {
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, p.errorAt(p.peek(), "expected ')'")
+		}
+		p.next()
+		return node, nil
+	}
+
+	if node, ok := p.tryPalindrome(); ok {
+		return node, nil
+	}
+	if node, ok, err := p.tryStringPredicate(); ok {
+		return node, err
+	}
+	if node, ok, err := p.tryQuantityPredicate(); ok {
+		return node, err
+	}
+
+	return nil, p.errorAt(p.peek(), "unrecognized predicate")
+}
+
+func (p *parser) tryPalindrome() (Node, bool) {
+	if p.acceptWord("non-palindrome") || p.acceptWord("non-palindromic") {
+		return PalindromePredicate{Want: false}, true
+	}
+	if p.acceptWord("palindrome") || p.acceptWord("palindromic") {
+		return PalindromePredicate{Want: true}, true
+	}
+	return nil, false
+}
+
+func (p *parser) tryStringPredicate() (Node, bool, error) {
+	if p.acceptPhrase("hash", "prefix") || p.acceptPhrase("hash", "starts", "with") {
+		node, err := p.parseQuotedPredicate(predHashPrefix, "hash prefix")
+		return node, true, err
+	}
+	if p.acceptPhrase("starts", "with") {
+		node, err := p.parseQuotedPredicate(predStartsWith, "starts with")
+		return node, true, err
+	}
+	if p.acceptPhrase("ends", "with") {
+		node, err := p.parseQuotedPredicate(predEndsWith, "ends with")
+		return node, true, err
+	}
+	if p.acceptPhrase("contains", "the", "word") {
+		node, err := p.parseQuotedPredicate(predContainsWord, "contains the word")
+		return node, true, err
+	}
+	if p.acceptPhrase("contains", "the", "character") {
+		node, err := p.parseCharacterPredicate()
+		return node, true, err
+	}
+	return nil, false, nil
+}
+
+func (p *parser) parseQuotedPredicate(kind stringKind, label string) (Node, error) {
+	tok := p.peek()
+	if tok.kind != tokString {
+		return nil, p.errorAt(tok, fmt.Sprintf("expected a quoted string after %q", label))
+	}
+	p.next()
+	return StringPredicate{Kind: kind, Value: tok.text}, nil
+}
+
+func (p *parser) parseCharacterPredicate() (Node, error) {
+	tok := p.peek()
+	if tok.kind != tokString || len([]rune(tok.text)) != 1 {
+		return nil, p.errorAt(tok, "expected a single-character quoted string")
+	}
+	p.next()
+	return StringPredicate{Kind: predContainsCharacter, Value: tok.text}, nil
+}
+
+func (p *parser) tryQuantityPredicate() (Node, bool, error) {
+	var f field
+	switch {
+	case p.acceptPhrase("unique", "character", "count"), p.acceptPhrase("unique", "characters"):
+		f = fieldUniqueCharacters
+	case p.acceptPhrase("word", "count"):
+		f = fieldWordCount
+	case p.acceptWord("length"):
+		f = fieldLength
+	default:
+		return nil, false, nil
+	}
+
+	cmp, ok := p.parseComparator()
+	if !ok {
+		return nil, true, p.errorAt(p.peek(), fmt.Sprintf("expected a comparator after %q", f))
+	}
+
+	lower, err := p.parseQuantity()
+	if err != nil {
+		return nil, true, err
+	}
+
+	if cmp != cmpBetween {
+		return Comparison{Field: f, Cmp: cmp, Value: lower}, true, nil
+	}
+
+	if !p.acceptWord("and") {
+		return nil, true, p.errorAt(p.peek(), "expected 'and' in 'between X and Y'")
+	}
+	upper, err := p.parseQuantity()
+	if err != nil {
+		return nil, true, err
+	}
+	return Comparison{Field: f, Cmp: cmpBetween, Value: lower, Upper: upper}, true, nil
+}
+
+func (p *parser) parseComparator() (comparator, bool) {
+	switch {
+	case p.acceptPhrase("at", "least"):
+		return cmpAtLeast, true
+	case p.acceptPhrase("at", "most"):
+		return cmpAtMost, true
+	case p.acceptPhrase("more", "than"):
+		return cmpMoreThan, true
+	case p.acceptPhrase("less", "than"):
+		return cmpLessThan, true
+	case p.acceptWord("exactly"):
+		return cmpExactly, true
+	case p.acceptWord("between"):
+		return cmpBetween, true
+	default:
+		return 0, false
+	}
+}
+
+// parseQuantity consumes a numeral, or a number word, and returns its
+// value. The only supported "hundred" composition is a bare "hundred" or
+// "one hundred" (both 100), since the supported range is zero through
+// one hundred; any other word before "hundred" (e.g. "two hundred") is
+// out of range and left unconsumed, which surfaces as a parse error from
+// the trailing "hundred" token rather than a silently wrong value....
+func (p *parser) parseQuantity() (int, error) {
+	tok := p.peek()
+
+	if tok.kind == tokNumber {
+		p.next()
+		return tok.num, nil
+	}
+
+	if tok.kind == tokWord {
+		if n, ok := wordToNumber(tok.text); ok {
+			p.next()
+			if n == 1 && p.isWord("hundred") {
+				p.next()
+				return 100, nil
+			}
+			return n, nil
+		}
+	}
+
+	return 0, p.errorAt(tok, "expected a number")
+}
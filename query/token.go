@@ -0,0 +1,24 @@
+package query
+
+// tokenKind identifies the lexical category of a token....
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokNumber
+	tokString
+	tokLParen
+	tokRParen
+)
+
+// token is a single lexical unit produced by lex. pos and raw are kept so
+// a parse error can point back at the exact span of the original query
+// that the parser choked on....
+type token struct {
+	kind tokenKind
+	text string // lowercased word, or unquoted string contents
+	num  int    // parsed value, only set when kind == tokNumber
+	pos  int    // rune offset into the original query
+	raw  string // original source slice covered by this token
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyStringPrefix    = "string:"
+	redisKeyCharIndexPrefix = "idx:char:"
+	redisKeyLengthIndex     = "idx:length"
+	redisKeyPalindromeSet   = "idx:palindromes"
+	redisKeyAllIDs          = "idx:ids"
+)
+
+// RedisStore is a Store backed by Redis. Each AnalyzedString is persisted
+// as JSON under string:<sha256>, alongside secondary indexes (a sorted set
+// on length, a set per contained character, and a set of palindromes) so
+// List can answer most filters through set operations instead of scanning
+// every record....
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore returns a RedisStore using client....
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func stringKey(id string) string {
+	return redisKeyStringPrefix + id
+}
+
+func charIndexKey(r rune) string {
+	return redisKeyCharIndexPrefix + string(r)
+}
+
+func (r *RedisStore) Create(s AnalyzedString) error {
+	ctx := context.Background()
+
+	exists, err := r.Exists(s.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrAlreadyExists
+	}
+
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal analyzed string: %w", err)
+	}
+
+	// SetNX inside the transaction closes the gap between the Exists check
+	// above and the write, so of two concurrent creates of the same ID only
+	// one succeeds. The index writes are identical for the same ID, so
+	// repeating them for the losing create changes nothing.
+	pipe := r.client.TxPipeline()
+	created := pipe.SetNX(ctx, stringKey(s.ID), payload, 0)
+	pipe.SAdd(ctx, redisKeyAllIDs, s.ID)
+	pipe.ZAdd(ctx, redisKeyLengthIndex, redis.Z{Score: float64(s.Properties.Length), Member: s.ID})
+	if s.Properties.IsPalindrome {
+		pipe.SAdd(ctx, redisKeyPalindromeSet, s.ID)
+	}
+	for ch := range s.Properties.CharacterFrequencyMap {
+		pipe.SAdd(ctx, charIndexKey(ch), s.ID)
+	}
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return err
+	}
+	if !created.Val() {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+func (r *RedisStore) Get(id string) (AnalyzedString, error) {
+	return r.getContext(context.Background(), id)
+}
+
+func (r *RedisStore) getContext(ctx context.Context, id string) (AnalyzedString, error) {
+	payload, err := r.client.Get(ctx, stringKey(id)).Bytes()
+	if err == redis.Nil {
+		return AnalyzedString{}, ErrNotFound
+	}
+	if err != nil {
+		return AnalyzedString{}, err
+	}
+
+	var s AnalyzedString
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return AnalyzedString{}, fmt.Errorf("unmarshal analyzed string: %w", err)
+	}
+	return s, nil
+}
+
+func (r *RedisStore) Delete(id string) error {
+	ctx := context.Background()
+
+	s, err := r.Get(id)
+	if err != nil {
+		return err
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, stringKey(id))
+	pipe.SRem(ctx, redisKeyAllIDs, id)
+	pipe.ZRem(ctx, redisKeyLengthIndex, id)
+	pipe.SRem(ctx, redisKeyPalindromeSet, id)
+	for ch := range s.Properties.CharacterFrequencyMap {
+		pipe.SRem(ctx, charIndexKey(ch), id)
+	}
+
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisStore) Exists(hash string) (bool, error) {
+	ctx := context.Background()
+
+	n, err := r.client.Exists(ctx, stringKey(hash)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *RedisStore) List(ctx context.Context, filters Filters, pagination Pagination) ([]AnalyzedString, int, error) {
+	sortFn, err := resolveSortFn(pagination.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	candidateIDs, err := r.candidateIDs(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matched := make([]AnalyzedString, 0, len(candidateIDs))
+	for i, id := range candidateIDs {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		s, err := r.getContext(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			continue // index briefly stale relative to a concurrent delete
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if filters.WordCount != nil && s.Properties.WordCount != *filters.WordCount {
+			continue
+		}
+		if filters.Predicate != nil && !filters.Predicate(s) {
+			continue
+		}
+		matched = append(matched, s)
+	}
+
+	page, total := paginate(matched, pagination.Offset, pagination.Limit, sortFn)
+	return page, total, nil
+}
+
+// candidateIDs resolves every filter with a Redis-native index (length,
+// palindrome, contained character) to a set of IDs via intersection. The
+// word_count filter and any Predicate have no index and are applied after
+// records are loaded....
+func (r *RedisStore) candidateIDs(ctx context.Context, filters Filters) ([]string, error) {
+	var sets [][]string
+
+	if filters.MinLength != nil || filters.MaxLength != nil {
+		min := "-inf"
+		max := "+inf"
+		if filters.MinLength != nil {
+			min = strconv.Itoa(*filters.MinLength)
+		}
+		if filters.MaxLength != nil {
+			max = strconv.Itoa(*filters.MaxLength)
+		}
+		ids, err := r.client.ZRangeByScore(ctx, redisKeyLengthIndex, &redis.ZRangeBy{Min: min, Max: max}).Result()
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, ids)
+	}
+
+	if filters.ContainsCharacter != nil {
+		ids, err := r.client.SMembers(ctx, charIndexKey(*filters.ContainsCharacter)).Result()
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, ids)
+	}
+
+	if filters.IsPalindrome != nil {
+		ids, err := r.palindromeCandidates(ctx, *filters.IsPalindrome)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, ids)
+	}
+
+	if len(sets) == 0 {
+		return r.client.SMembers(ctx, redisKeyAllIDs).Result()
+	}
+
+	return intersect(sets), nil
+}
+
+// palindromeCandidates returns the IDs of palindromes (want=true) or of
+// everything else (want=false)....
+func (r *RedisStore) palindromeCandidates(ctx context.Context, want bool) ([]string, error) {
+	palindromes, err := r.client.SMembers(ctx, redisKeyPalindromeSet).Result()
+	if err != nil {
+		return nil, err
+	}
+	if want {
+		return palindromes, nil
+	}
+
+	all, err := r.client.SMembers(ctx, redisKeyAllIDs).Result()
+	if err != nil {
+		return nil, err
+	}
+	excluded := make(map[string]bool, len(palindromes))
+	for _, id := range palindromes {
+		excluded[id] = true
+	}
+
+	nonPalindromes := make([]string, 0, len(all))
+	for _, id := range all {
+		if !excluded[id] {
+			nonPalindromes = append(nonPalindromes, id)
+		}
+	}
+	return nonPalindromes, nil
+}
+
+// intersect returns the members present in every set....
+func intersect(sets [][]string) []string {
+	counts := make(map[string]int)
+	for _, set := range sets {
+		seen := make(map[string]bool, len(set))
+		for _, id := range set {
+			if !seen[id] {
+				counts[id]++
+				seen[id] = true
+			}
+		}
+	}
+
+	result := make([]string, 0)
+	for id, count := range counts {
+		if count == len(sets) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// ctxCheckInterval is how often List implementations check ctx.Err() while
+// scanning, so a cancelled or timed-out request can't hold a store lock (or
+// block on Redis round trips) indefinitely....
+const ctxCheckInterval = 1024
+
+// ErrNotFound is returned by Store methods when the requested string does
+// not exist....
+var ErrNotFound = errors.New("string not found in the system")
+
+// ErrAlreadyExists is returned by Store.Create when a string with the same
+// hash has already been stored....
+var ErrAlreadyExists = errors.New("string already exists in the system")
+
+// ErrInvalidSort is returned by resolveSortFn when Pagination.Sort is set
+// but is not one of the keys in validSortFields. Callers can match it with
+// errors.Is to tell this client error apart from a genuine backend
+// failure surfaced by List....
+var ErrInvalidSort = errors.New("invalid value for 'sort', must be one of created_at, -created_at, length, -length, word_count, -word_count")
+
+// Filters describes the predicate filters applied by a List call. A nil
+// field means "no filter on this dimension". Predicate, when set, is an
+// arbitrary additional predicate (e.g. compiled from a natural language
+// query) evaluated against every candidate that passes the structured
+// fields above....
+type Filters struct {
+	IsPalindrome      *bool
+	MinLength         *int
+	MaxLength         *int
+	WordCount         *int
+	ContainsCharacter *rune
+	Predicate         func(AnalyzedString) bool
+}
+
+// Pagination describes the offset, limit, and sort order applied by a
+// List call. Sort must be empty or one of the keys in validSortFields....
+type Pagination struct {
+	Offset int
+	Limit  int
+	Sort   string
+}
+
+// Store is the persistence interface for analyzed strings. Implementations
+// must be safe for concurrent use....
+type Store interface {
+	// Create stores s, returning ErrAlreadyExists if s.ID is already present.
+	Create(s AnalyzedString) error
+	// Get returns the string stored under id, or ErrNotFound.
+	Get(id string) (AnalyzedString, error)
+	// Delete removes the string stored under id, or returns ErrNotFound.
+	Delete(id string) error
+	// List returns the strings matching filters, sorted and paginated per
+	// pagination, along with the total number of matches before pagination.
+	// It returns ctx.Err() if ctx is cancelled or times out before the scan
+	// completes.
+	List(ctx context.Context, filters Filters, pagination Pagination) ([]AnalyzedString, int, error)
+	// Exists reports whether a string with the given hash is stored.
+	Exists(hash string) (bool, error)
+}
+
+// validSortFields maps the allowed Pagination.Sort values to a comparator
+// that reports whether a should sort before b....
+var validSortFields = map[string]func(a, b AnalyzedString) bool{
+	"created_at":  func(a, b AnalyzedString) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	"-created_at": func(a, b AnalyzedString) bool { return a.CreatedAt.After(b.CreatedAt) },
+	"length":      func(a, b AnalyzedString) bool { return a.Properties.Length < b.Properties.Length },
+	"-length":     func(a, b AnalyzedString) bool { return a.Properties.Length > b.Properties.Length },
+	"word_count":  func(a, b AnalyzedString) bool { return a.Properties.WordCount < b.Properties.WordCount },
+	"-word_count": func(a, b AnalyzedString) bool { return a.Properties.WordCount > b.Properties.WordCount },
+}
+
+// resolveSortFn looks up the comparator for a Pagination.Sort value. An
+// empty sort leaves ordering unspecified (nil comparator, no error)....
+func resolveSortFn(sortParam string) (func(a, b AnalyzedString) bool, error) {
+	if sortParam == "" {
+		return nil, nil
+	}
+	fn, ok := validSortFields[sortParam]
+	if !ok {
+		return nil, ErrInvalidSort
+	}
+	return fn, nil
+}
+
+// matchesFilters reports whether s satisfies every set field in f....
+func matchesFilters(s AnalyzedString, f Filters) bool {
+	if f.IsPalindrome != nil && s.Properties.IsPalindrome != *f.IsPalindrome {
+		return false
+	}
+	if f.MinLength != nil && s.Properties.Length < *f.MinLength {
+		return false
+	}
+	if f.MaxLength != nil && s.Properties.Length > *f.MaxLength {
+		return false
+	}
+	if f.WordCount != nil && s.Properties.WordCount != *f.WordCount {
+		return false
+	}
+	if f.ContainsCharacter != nil {
+		if _, ok := s.Properties.CharacterFrequencyMap[*f.ContainsCharacter]; !ok {
+			return false
+		}
+	}
+	if f.Predicate != nil && !f.Predicate(s) {
+		return false
+	}
+	return true
+}
+
+// paginate sorts (if sortFn is set) and slices matched into a page,
+// returning the page and the total number of matches before slicing....
+func paginate(matched []AnalyzedString, offset, limit int, sortFn func(a, b AnalyzedString) bool) ([]AnalyzedString, int) {
+	total := len(matched)
+
+	if sortFn != nil {
+		sort.Slice(matched, func(i, j int) bool { return sortFn(matched[i], matched[j]) })
+	}
+
+	if offset >= total {
+		return make([]AnalyzedString, 0), total
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return matched[offset:end], total
+}